@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"emperror.dev/emperror"
 	"emperror.dev/errors"
@@ -29,6 +30,7 @@ import (
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/metrics"
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
 	"github.com/banzaicloud/cloudinfo/internal/platform/log"
+	cloudinfoapi "github.com/banzaicloud/cloudinfo/pkg/cloudinfo"
 )
 
 // AlibabaInfoer encapsulates the data and operations needed to access external Alibaba resources
@@ -110,6 +112,51 @@ func (a *AlibabaInfoer) getCurrentSpotPrices(region string) (map[string]types.Sp
 	return priceInfo, nil
 }
 
+// GetSpotPriceHistory returns the spot price series observed in each of the given zones for an instance
+// type, covering the requested time window. Unlike getCurrentSpotPrices, which keeps only the latest
+// point per zone, this calls DescribeSpotPriceHistory with an explicit StartTime/EndTime and retains
+// every point returned whose timestamp parses as RFC3339; points with any other timestamp are skipped,
+// and a zone whose request fails is left out of the result
+func (a *AlibabaInfoer) GetSpotPriceHistory(region, instanceType string, zones []string, window time.Duration) (map[string]cloudinfoapi.SpotPriceSeries, error) {
+	logger := log.WithFields(a.log, map[string]interface{}{"region": region, "instanceType": instanceType})
+	logger.Debug("getting spot price history")
+
+	end := time.Now()
+	start := end.Add(-window)
+
+	history := make(map[string]cloudinfoapi.SpotPriceSeries, len(zones))
+
+	for _, zone := range zones {
+		describeSpotPriceHistory, err := a.client.ProcessCommonRequest(a.describeSpotPriceHistoryWindowRequest(region, zone, instanceType, start, end))
+		if err != nil {
+			logger.Error("failed to get spot price history", map[string]interface{}{"zone": zone})
+			continue
+		}
+
+		response := &ecs.DescribeSpotPriceHistoryResponse{}
+		if err := json.Unmarshal(describeSpotPriceHistory.BaseResponse.GetHttpContentBytes(), response); err != nil {
+			return nil, err
+		}
+
+		series := make(cloudinfoapi.SpotPriceSeries, 0, len(response.SpotPrices.SpotPriceType))
+		for _, priceType := range response.SpotPrices.SpotPriceType {
+			timestamp, err := time.Parse(time.RFC3339, priceType.Timestamp)
+			if err != nil {
+				continue
+			}
+			series = append(series, cloudinfoapi.SpotPricePoint{
+				Timestamp: timestamp,
+				Zone:      zone,
+				Price:     priceType.SpotPrice,
+			})
+		}
+		history[zone] = series
+	}
+
+	logger.Debug("retrieved spot price history", map[string]interface{}{"numberOfZones": len(history)})
+	return history, nil
+}
+
 func (a *AlibabaInfoer) getZones(region string) ([]ecs.Zone, error) {
 	describeZones, err := a.client.ProcessCommonRequest(a.describeZonesRequest(region))
 	if err != nil {
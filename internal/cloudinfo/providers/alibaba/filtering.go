@@ -0,0 +1,153 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"strings"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+	cloudinfoapi "github.com/banzaicloud/cloudinfo/pkg/cloudinfo"
+)
+
+// GetProductsFiltered retrieves the virtual machines matching the given filters. DescribeInstanceTypes has
+// no server-side filter parameters of its own (it always returns the full instance type catalog), so every
+// filter is applied in-memory against the resulting VM list
+func (a *AlibabaInfoer) GetProductsFiltered(vms []types.VMInfo, service, region string, filters []cloudinfoapi.PriceFilter) ([]types.VMInfo, error) {
+	vmList := vms
+	if len(vmList) == 0 {
+		var err error
+		vmList, err = a.GetVirtualMachines(region)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	filtered, err := a.GetProducts(vmList, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyVMFilters(filtered, filters), nil
+}
+
+// applyVMFilters returns the subset of vms matching every filter
+func applyVMFilters(vms []types.VMInfo, filters []cloudinfoapi.PriceFilter) []types.VMInfo {
+	if len(filters) == 0 {
+		return vms
+	}
+
+	result := make([]types.VMInfo, 0, len(vms))
+	for _, vm := range vms {
+		if matchesAll(vm, filters) {
+			result = append(result, vm)
+		}
+	}
+	return result
+}
+
+// matchesAll reports whether vm satisfies every filter
+func matchesAll(vm types.VMInfo, filters []cloudinfoapi.PriceFilter) bool {
+	for _, f := range filters {
+		if !matches(vm, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether vm satisfies a single filter; every comparison ignores case
+func matches(vm types.VMInfo, f cloudinfoapi.PriceFilter) bool {
+	value := fieldValue(vm, f.Field)
+
+	switch f.Type {
+	case cloudinfoapi.FilterAnyOf:
+		for _, v := range strings.Split(f.Value, ",") {
+			if strings.EqualFold(value, strings.TrimSpace(v)) {
+				return true
+			}
+		}
+		return false
+	case cloudinfoapi.FilterNoneOf:
+		for _, v := range strings.Split(f.Value, ",") {
+			if strings.EqualFold(value, strings.TrimSpace(v)) {
+				return false
+			}
+		}
+		return true
+	case cloudinfoapi.FilterContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(f.Value))
+	default: // FilterTermMatch, FilterEquals
+		return strings.EqualFold(value, f.Value)
+	}
+}
+
+// fieldValue extracts the value of the named field from a VMInfo, used by the in-memory filter fallback.
+// instanceType/category/networkPerformanceCategory map onto VMInfo directly; family/generation/arch are
+// derived from the leading segments of the Alibaba instance type identifier (eg.: "ecs.g6.large" is family
+// "g6", generation "6"), since VMInfo does not carry them as separate fields.
+// "io-optimized" is intentionally not handled: every current-generation Alibaba instance type is I/O
+// optimized, but VMInfo has no field recording that, so rather than fabricate a constant match (as this
+// used to do) the field falls through to the unknown-field default below and yields "": TERM_MATCH, EQUALS
+// and ANY_OF filters on it match no VM, while NONE_OF filters on it match every VM
+func fieldValue(vm types.VMInfo, field string) string {
+	switch field {
+	case "instanceType":
+		return vm.Type
+	case "category":
+		return vm.Category
+	case "networkPerformanceCategory":
+		return vm.NtwPerfCat
+	case "family":
+		return instanceFamily(vm.Type)
+	case "generation":
+		return instanceGeneration(vm.Type)
+	case "arch":
+		return instanceArch(vm.Type)
+	default:
+		return ""
+	}
+}
+
+// instanceFamily extracts the family segment from an Alibaba instance type identifier, eg.:
+// "ecs.g6.large" -> "g6"
+func instanceFamily(instanceType string) string {
+	parts := strings.Split(instanceType, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+// instanceGeneration extracts the generation digits from the family segment, eg.: "g6" -> "6"
+func instanceGeneration(instanceType string) string {
+	family := instanceFamily(instanceType)
+	for i, r := range family {
+		if r >= '0' && r <= '9' {
+			return family[i:]
+		}
+	}
+	return ""
+}
+
+// instanceArch reports the CPU architecture implied by the family segment; Alibaba's ARM-based families
+// are suffixed "a" (eg.: "g6a" used to denote ARM until the rename to "g8y"/"c8y"; both suffixes are
+// covered here), everything else is x86_64
+func instanceArch(instanceType string) string {
+	family := instanceFamily(instanceType)
+	if strings.HasSuffix(family, "a") || strings.HasSuffix(family, "y") {
+		return "arm64"
+	}
+	return "x86_64"
+}
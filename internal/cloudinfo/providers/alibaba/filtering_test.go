@@ -0,0 +1,79 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"testing"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+	cloudinfoapi "github.com/banzaicloud/cloudinfo/pkg/cloudinfo"
+)
+
+func TestApplyVMFilters(t *testing.T) {
+	vms := []types.VMInfo{
+		{Type: "ecs.g6.large", Category: "General", NtwPerfCat: "high"},
+		{Type: "ecs.c6.large", Category: "Compute", NtwPerfCat: "low"},
+		{Type: "ecs.g6a.large", Category: "General", NtwPerfCat: "high"},
+	}
+
+	tests := map[string]struct {
+		filters []cloudinfoapi.PriceFilter
+		want    []string
+	}{
+		"term match on instanceType": {
+			filters: []cloudinfoapi.PriceFilter{{Field: "instanceType", Type: cloudinfoapi.FilterTermMatch, Value: "ecs.c6.large"}},
+			want:    []string{"ecs.c6.large"},
+		},
+		"any of on family": {
+			filters: []cloudinfoapi.PriceFilter{{Field: "family", Type: cloudinfoapi.FilterAnyOf, Value: "g6,g6a"}},
+			want:    []string{"ecs.g6.large", "ecs.g6a.large"},
+		},
+		"none of on category": {
+			filters: []cloudinfoapi.PriceFilter{{Field: "category", Type: cloudinfoapi.FilterNoneOf, Value: "Compute"}},
+			want:    []string{"ecs.g6.large", "ecs.g6a.large"},
+		},
+		"contains on networkPerformanceCategory": {
+			filters: []cloudinfoapi.PriceFilter{{Field: "networkPerformanceCategory", Type: cloudinfoapi.FilterContains, Value: "hi"}},
+			want:    []string{"ecs.g6.large", "ecs.g6a.large"},
+		},
+		"arch filters to arm64 family": {
+			filters: []cloudinfoapi.PriceFilter{{Field: "arch", Type: cloudinfoapi.FilterEquals, Value: "arm64"}},
+			want:    []string{"ecs.g6a.large"},
+		},
+		"no filters returns everything": {
+			filters: nil,
+			want:    []string{"ecs.g6.large", "ecs.c6.large", "ecs.g6a.large"},
+		},
+		"unbacked io-optimized field matches nothing": {
+			filters: []cloudinfoapi.PriceFilter{{Field: "io-optimized", Type: cloudinfoapi.FilterEquals, Value: "optimized"}},
+			want:    []string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := applyVMFilters(vms, test.filters)
+
+			if len(got) != len(test.want) {
+				t.Fatalf("expected %d results, got %d: %v", len(test.want), len(got), got)
+			}
+			for i, vm := range got {
+				if vm.Type != test.want[i] {
+					t.Errorf("expected result %d to be %q, got %q", i, test.want[i], vm.Type)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+	cloudinfoapi "github.com/banzaicloud/cloudinfo/pkg/cloudinfo"
+)
+
+const (
+	// catalogResyncPeriod is how often the VM catalog informer re-lists; the catalog changes rarely
+	catalogResyncPeriod = time.Hour
+
+	// priceResyncPeriod is how often the price informer re-lists; prices are far more volatile than the catalog
+	priceResyncPeriod = time.Minute
+)
+
+// List implements cloudinfo.ListWatcher. The Alibaba SDK has no native watch API, so the informer layer
+// calls List on every resync and diffs consecutive results by ResourceKey and version hash to synthesise
+// Add/Update/Delete deltas
+func (a *AlibabaInfoer) List(kind cloudinfoapi.ResourceKind, region string) (map[string]interface{}, error) {
+	switch kind {
+	case cloudinfoapi.ResourceKindVM:
+		vms, err := a.GetVirtualMachines(region)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]interface{}, len(vms))
+		for _, vm := range vms {
+			result[cloudinfoapi.ResourceKey("alibaba", region, vm.Type)] = vm
+		}
+		return result, nil
+
+	case cloudinfoapi.ResourceKindPrice:
+		prices, err := a.GetCurrentPrices(region)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make(map[string]interface{}, len(prices))
+		for instanceType, price := range prices {
+			result[cloudinfoapi.ResourceKey("alibaba", region, instanceType)] = price
+		}
+		return result, nil
+
+	default:
+		return nil, errors.Errorf("unsupported resource kind: %s", kind)
+	}
+}
+
+// NewVMInformer returns a SharedIndexInformer that tracks the VM catalog for region, backed by this
+// Infoer's List implementation and resynced on catalogResyncPeriod
+func (a *AlibabaInfoer) NewVMInformer(region string) cloudinfoapi.SharedIndexInformer {
+	return cloudinfoapi.NewSharedIndexInformer("alibaba", region, cloudinfoapi.ResourceKindVM, a, catalogResyncPeriod, nil, nil)
+}
+
+// NewPriceInformer returns a SharedIndexInformer that tracks the prices GetCurrentPrices reports for region
+// (per-zone spot prices, with OnDemandPrice always -1), resynced on priceResyncPeriod. When priceEvents is
+// non-nil, a PriceEvent is sent for every instance type whose OnDemandPrice changes between resyncs; since
+// GetCurrentPrices never reports an on-demand price, spot price changes alone produce no PriceEvent
+func (a *AlibabaInfoer) NewPriceInformer(region string, priceEvents chan<- cloudinfoapi.PriceEvent) cloudinfoapi.SharedIndexInformer {
+	return cloudinfoapi.NewSharedIndexInformer("alibaba", region, cloudinfoapi.ResourceKindPrice, a, priceResyncPeriod, onDemandPriceExtractor, priceEvents)
+}
+
+// onDemandPriceExtractor is the cloudinfoapi.PriceExtractor for objects produced by List(ResourceKindPrice, ...)
+func onDemandPriceExtractor(obj interface{}) (float64, bool) {
+	price, ok := obj.(types.Price)
+	if !ok {
+		return 0, false
+	}
+	return price.OnDemandPrice, true
+}
@@ -0,0 +1,35 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"testing"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+func TestOnDemandPriceExtractor(t *testing.T) {
+	price, ok := onDemandPriceExtractor(types.Price{OnDemandPrice: 0.42})
+	if !ok {
+		t.Fatal("expected a types.Price to be extractable")
+	}
+	if price != 0.42 {
+		t.Errorf("expected 0.42, got %v", price)
+	}
+
+	if _, ok := onDemandPriceExtractor("not a price"); ok {
+		t.Error("expected a non-Price object to not be extractable")
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"encoding/json"
+
+	"emperror.dev/emperror"
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/cloudinfo/internal/platform/log"
+	cloudinfoapi "github.com/banzaicloud/cloudinfo/pkg/cloudinfo"
+)
+
+// ackDisallowedFamilies lists instance families ACK will not schedule nodes on (eg.: burstable t5/t6,
+// which ACK rejects for control-plane stability reasons)
+var ackDisallowedFamilies = []string{"ecs.t5", "ecs.t6"}
+
+// ackPlaceholderCapabilities holds fixed ACK defaults for the node pool fields that GetNodePoolCapabilities
+// does not look up through any API call: node count bounds, image and CNI choices, and the spot/GPU/ARM
+// support flags. The same values are returned for every region. There is no confidential-VM entry, so
+// SupportsConfidential is always false.
+var ackPlaceholderCapabilities = struct {
+	minNodes, maxNodes                     int
+	images, networkPlugins                 []string
+	supportsSpot, supportsGPU, supportsARM bool
+}{
+	minNodes:       1,
+	maxNodes:       100,
+	images:         []string{"CentOS", "AliyunLinux", "Windows"},
+	networkPlugins: []string{"Flannel", "Terway"},
+	supportsSpot:   true,
+	supportsGPU:    true,
+	supportsARM:    true,
+}
+
+// GetNodePoolCapabilities returns what an ACK node pool can be built with in a region. Any other service
+// returns an error.
+// Only InstanceFamilies is sourced from a live API call (DescribeClusterResources). Versions come from
+// GetVersions, which returns a fixed version list; MinNodes/MaxNodes, Images, NetworkPlugins and the
+// SupportsSpot/SupportsGPU/SupportsARM flags are fixed ACK defaults (see ackPlaceholderCapabilities), and
+// SupportsConfidential is always false.
+func (a *AlibabaInfoer) GetNodePoolCapabilities(service, region string) (cloudinfoapi.NodePoolCapabilities, error) {
+	if service != svcAck {
+		return cloudinfoapi.NodePoolCapabilities{}, errors.Wrap(errors.New(service), "invalid service")
+	}
+
+	logger := log.WithFields(a.log, map[string]interface{}{"service": service, "region": region})
+	logger.Debug("getting node pool capabilities")
+
+	clusterResources, err := a.client.ProcessCommonRequest(a.describeClusterResourcesRequest(region))
+	if err != nil {
+		return cloudinfoapi.NodePoolCapabilities{}, emperror.Wrap(err, "DescribeClusterResources API call problem")
+	}
+
+	var resourcesResponse struct {
+		InstanceTypeFamilies []string `json:"instance_type_families"`
+	}
+	if err := json.Unmarshal(clusterResources.BaseResponse.GetHttpContentBytes(), &resourcesResponse); err != nil {
+		return cloudinfoapi.NodePoolCapabilities{}, err
+	}
+	families := resourcesResponse.InstanceTypeFamilies
+
+	versions, err := a.GetVersions(service, region)
+	if err != nil {
+		return cloudinfoapi.NodePoolCapabilities{}, err
+	}
+
+	var versionStrings []string
+	for _, v := range versions {
+		versionStrings = append(versionStrings, v.Version)
+	}
+
+	logger.Debug("node pool capabilities include placeholder fields pending DescribeKubernetesVersionMetadata integration",
+		map[string]interface{}{"placeholderFields": "minNodes,maxNodes,images,networkPlugins,supportsSpot,supportsGPU,supportsARM"})
+
+	capabilities := cloudinfoapi.NodePoolCapabilities{
+		InstanceFamilies: families,
+		DisallowedTypes:  ackDisallowedFamilies,
+		MinNodes:         ackPlaceholderCapabilities.minNodes,
+		MaxNodes:         ackPlaceholderCapabilities.maxNodes,
+		Images:           ackPlaceholderCapabilities.images,
+		NetworkPlugins:   ackPlaceholderCapabilities.networkPlugins,
+		Versions:         versionStrings,
+		SupportsSpot:     ackPlaceholderCapabilities.supportsSpot,
+		SupportsGPU:      ackPlaceholderCapabilities.supportsGPU,
+		SupportsARM:      ackPlaceholderCapabilities.supportsARM,
+	}
+
+	return capabilities, nil
+}
@@ -0,0 +1,34 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"reflect"
+	"testing"
+
+	cloudinfoapi "github.com/banzaicloud/cloudinfo/pkg/cloudinfo"
+)
+
+func TestGetNodePoolCapabilitiesInvalidService(t *testing.T) {
+	infoer := &AlibabaInfoer{}
+
+	capabilities, err := infoer.GetNodePoolCapabilities("eks", "eu-central-1")
+	if err == nil {
+		t.Fatal("expected an error for a non-ACK service")
+	}
+	if !reflect.DeepEqual(capabilities, cloudinfoapi.NodePoolCapabilities{}) {
+		t.Errorf("expected zero-value capabilities, got %+v", capabilities)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/bssopenapi"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+)
+
+// describeAvailableResourceRequest builds a DescribeAvailableResource request for the given zone,
+// resource type (eg.: "disk") and destination resource (eg.: "DataDisk")
+func (a *AlibabaInfoer) describeAvailableResourceRequest(region, zoneId, resourceType, destinationResource string) *requests.CommonRequest {
+	req := ecs.CreateDescribeAvailableResourceRequest()
+	req.RegionId = region
+	req.ZoneId = zoneId
+	req.DestinationResource = destinationResource
+	req.ResourceType = resourceType
+
+	return req.CommonRequest
+}
+
+// getDiskPayAsYouGoPriceRequest builds a GetPayAsYouGoPrice request quoting a disk of sizeGB in the given
+// category; the caller is responsible for normalising the quoted total down to a per-GB rate
+func (a *AlibabaInfoer) getDiskPayAsYouGoPriceRequest(region, category string, sizeGB int) *requests.CommonRequest {
+	req := bssopenapi.CreateGetPayAsYouGoPriceRequest()
+	req.ProductCode = "ecs"
+	req.SubscriptionType = "PayAsYouGo"
+	req.ModuleList = fmt.Sprintf(`[{"ModuleCode":"disk","Config":"region:%s,diskCategory:%s,size:%d"}]`, region, category, sizeGB)
+
+	return req.CommonRequest
+}
+
+// getSubscriptionPriceRequest builds a GetSubscriptionPrice request for the given instance type and subscription
+// length (in months)
+func (a *AlibabaInfoer) getSubscriptionPriceRequest(region, instanceType string, months int) *requests.CommonRequest {
+	req := bssopenapi.CreateGetSubscriptionPriceRequest()
+	req.ProductCode = "ecs"
+	req.SubscriptionType = "Subscription"
+	req.OrderType = "NEW"
+	req.Region = region
+	req.ServicePeriodQuantity = requests.NewInteger(months)
+	req.ServicePeriodUnit = "Month"
+	req.Config = fmt.Sprintf("region:%s,instanceType:%s,ioOptimized:optimized,networkType:vpc", region, instanceType)
+
+	return req.CommonRequest
+}
+
+// describeClusterResourcesRequest builds a DescribeClusterResources request against the CS (Container
+// Service) endpoint for the given region, used to discover ACK node pool capabilities
+func (a *AlibabaInfoer) describeClusterResourcesRequest(region string) *requests.CommonRequest {
+	req := requests.NewCommonRequest()
+	req.Method = "GET"
+	req.Product = "CS"
+	req.Version = "2015-12-15"
+	req.Domain = "cs.aliyuncs.com"
+	req.PathPattern = "/resources"
+	req.QueryParams["region"] = region
+
+	return req
+}
+
+// describeSpotPriceHistoryWindowRequest builds a DescribeSpotPriceHistory request scoped to a single zone
+// and covering [start, end], as opposed to the unscoped, latest-point-only request used by
+// getCurrentSpotPrices
+func (a *AlibabaInfoer) describeSpotPriceHistoryWindowRequest(region, zoneId, instanceType string, start, end time.Time) *requests.CommonRequest {
+	req := ecs.CreateDescribeSpotPriceHistoryRequest()
+	req.RegionId = region
+	req.ZoneId = zoneId
+	req.InstanceType = instanceType
+	req.StartTime = start.Format(time.RFC3339)
+	req.EndTime = end.Format(time.RFC3339)
+
+	return req.CommonRequest
+}
@@ -0,0 +1,102 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"encoding/json"
+
+	"emperror.dev/emperror"
+	"emperror.dev/errors"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/bssopenapi"
+
+	"github.com/banzaicloud/cloudinfo/internal/platform/log"
+	cloudinfoapi "github.com/banzaicloud/cloudinfo/pkg/cloudinfo"
+)
+
+// subscriptionTerms are the subscription lengths (in months) Alibaba sells for ECS, paired with the
+// cloudinfo term length they map to
+var subscriptionTerms = []struct {
+	months int
+	term   string
+}{
+	{12, cloudinfoapi.TermLength1yr},
+	{36, cloudinfoapi.TermLength3yr},
+}
+
+// GetReservedPrice returns the subscription pricing terms available for the given instance type in a region.
+// Alibaba has no reserved-instance concept of its own, so this is derived from the yearly/monthly subscription
+// price, which is always paid upfront for the full term (there is no partial/no-upfront equivalent)
+func (a *AlibabaInfoer) GetReservedPrice(region, instanceType string) ([]cloudinfoapi.ReservedTerm, error) {
+	logger := log.WithFields(a.log, map[string]interface{}{"region": region, "instanceType": instanceType})
+	logger.Debug("getting reserved price")
+
+	var terms []cloudinfoapi.ReservedTerm
+
+	for _, st := range subscriptionTerms {
+		upfrontFee, err := a.getSubscriptionPrice(region, instanceType, st.months)
+		if err != nil {
+			logger.Debug("no subscription price for instance type", map[string]interface{}{"months": st.months})
+			continue
+		}
+
+		hours := subscriptionTermHours(st.months)
+		terms = append(terms, cloudinfoapi.ReservedTerm{
+			TermLength:      st.term,
+			PaymentOption:   cloudinfoapi.PaymentAllUpfront,
+			OfferingClass:   cloudinfoapi.OfferingStandard,
+			UpfrontFee:      upfrontFee,
+			HourlyRate:      0,
+			EffectiveHourly: effectiveHourlyRate(upfrontFee, 0, hours),
+		})
+	}
+
+	logger.Debug("found reserved prices", map[string]interface{}{"numberOfTerms": len(terms)})
+	return terms, nil
+}
+
+// getSubscriptionPrice returns the total subscription price for the given instance type and term length
+func (a *AlibabaInfoer) getSubscriptionPrice(region, instanceType string, months int) (float64, error) {
+	getSubscriptionPrice, err := a.client.ProcessCommonRequest(a.getSubscriptionPriceRequest(region, instanceType, months))
+	if err != nil {
+		return 0, emperror.Wrap(err, "GetSubscriptionPrice API call problem")
+	}
+
+	response := &bssopenapi.GetSubscriptionPriceResponse{}
+	if err := json.Unmarshal(getSubscriptionPrice.BaseResponse.GetHttpContentBytes(), response); err != nil {
+		return 0, err
+	}
+
+	if !response.Success {
+		return 0, emperror.With(errors.New("failed to get subscription price"), response.Code)
+	}
+
+	return response.Data.TradePrice, nil
+}
+
+// subscriptionTermHours converts a subscription length in months to hours, using a 30-day month as
+// Alibaba's billing does
+func subscriptionTermHours(months int) float64 {
+	return float64(months) * 30 * 24
+}
+
+// effectiveHourlyRate amortises upfrontFee over termHours and adds hourlyRate, giving the comparable
+// hourly cost of a reserved term against on-demand pricing. Returns hourlyRate unamortised if termHours
+// is not positive
+func effectiveHourlyRate(upfrontFee, hourlyRate, termHours float64) float64 {
+	if termHours <= 0 {
+		return hourlyRate
+	}
+	return hourlyRate + upfrontFee/termHours
+}
@@ -0,0 +1,57 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import "testing"
+
+func TestSubscriptionTermHours(t *testing.T) {
+	tests := map[string]struct {
+		months int
+		want   float64
+	}{
+		"1yr term":  {months: 12, want: 8640},
+		"3yr term":  {months: 36, want: 25920},
+		"no months": {months: 0, want: 0},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := subscriptionTermHours(test.months); got != test.want {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestEffectiveHourlyRate(t *testing.T) {
+	tests := map[string]struct {
+		upfrontFee float64
+		hourlyRate float64
+		termHours  float64
+		want       float64
+	}{
+		"all upfront amortised": {upfrontFee: 8640, hourlyRate: 0, termHours: 8640, want: 1},
+		"upfront plus hourly":   {upfrontFee: 4320, hourlyRate: 0.1, termHours: 8640, want: 0.6},
+		"zero term hours":       {upfrontFee: 8640, hourlyRate: 0.2, termHours: 0, want: 0.2},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := effectiveHourlyRate(test.upfrontFee, test.hourlyRate, test.termHours); got != test.want {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"encoding/json"
+
+	"emperror.dev/emperror"
+	"emperror.dev/errors"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/bssopenapi"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+
+	"github.com/banzaicloud/cloudinfo/internal/platform/log"
+	cloudinfoapi "github.com/banzaicloud/cloudinfo/pkg/cloudinfo"
+)
+
+const (
+	resourceTypeDisk        = "disk"
+	destinationResourceDisk = "DataDisk"
+
+	// diskPriceQuoteSizeGB is the disk size GetPayAsYouGoPrice is quoted against; the quoted total is
+	// divided by this to arrive at a per-GB rate
+	diskPriceQuoteSizeGB = 20
+)
+
+// diskCapabilities holds the provisionable size range (in GB) and the IOPS/throughput performance class
+// for the well known ECS disk categories. Alibaba does not return any of this from DescribeAvailableResource,
+// so it is kept as a lookup table (see the ECS disk category reference for the published per-category limits).
+var diskCapabilities = map[string]struct {
+	minSizeGB       int
+	maxSizeGB       int
+	iopsClass       string
+	throughputClass string
+}{
+	"cloud":            {5, 2000, "PL0", "low"},
+	"cloud_efficiency": {20, 32768, "PL0", "medium"},
+	"cloud_ssd":        {20, 32768, "PL1", "high"},
+	"cloud_essd":       {20, 32768, "PL1", "high"},
+	"cloud_auto":       {20, 32768, "PL1", "medium"},
+}
+
+// GetStorageProducts retrieves the block storage types available per zone in a given region, along with their
+// on-demand per-GB price
+func (a *AlibabaInfoer) GetStorageProducts(region string) ([]cloudinfoapi.StorageInfo, error) {
+	logger := log.WithFields(a.log, map[string]interface{}{"region": region})
+	logger.Debug("getting storage products")
+
+	availableZones, err := a.getZones(region)
+	if err != nil {
+		return nil, err
+	}
+
+	storageByCategory := make(map[string]*cloudinfoapi.StorageInfo)
+
+	for _, zone := range availableZones {
+		describeAvailableResource, err := a.client.ProcessCommonRequest(a.describeAvailableResourceRequest(region, zone.ZoneId, resourceTypeDisk, destinationResourceDisk))
+		if err != nil {
+			logger.Debug(emperror.Wrap(err, "DescribeAvailableResource API call problem").Error(), map[string]interface{}{"zone": zone.ZoneId})
+			continue
+		}
+
+		response := &ecs.DescribeAvailableResourceResponse{}
+		if err := json.Unmarshal(describeAvailableResource.BaseResponse.GetHttpContentBytes(), response); err != nil {
+			return nil, err
+		}
+
+		for _, availableZone := range response.AvailableZones.AvailableZone {
+			for _, resource := range availableZone.AvailableResources.AvailableResource {
+				for _, supportedResource := range resource.SupportedResources.SupportedResource {
+					category := supportedResource.Value
+
+					info, ok := storageByCategory[category]
+					if !ok {
+						capabilities := diskCapabilities[category]
+						info = &cloudinfoapi.StorageInfo{
+							Type:            cloudinfoapi.StorageType(category),
+							MinSizeGB:       capabilities.minSizeGB,
+							MaxSizeGB:       capabilities.maxSizeGB,
+							IopsClass:       capabilities.iopsClass,
+							ThroughputClass: capabilities.throughputClass,
+						}
+						storageByCategory[category] = info
+					}
+					info.Zones = append(info.Zones, zone.ZoneId)
+				}
+			}
+		}
+	}
+
+	for category, info := range storageByCategory {
+		price, err := a.getDiskPrice(region, category)
+		if err != nil {
+			logger.Debug("no price for disk category", map[string]interface{}{"category": category})
+			continue
+		}
+		info.PricePerGB = price
+	}
+
+	storageTypes := make([]cloudinfoapi.StorageInfo, 0, len(storageByCategory))
+	for _, info := range storageByCategory {
+		storageTypes = append(storageTypes, *info)
+	}
+
+	logger.Debug("found storage types", map[string]interface{}{"numberOfStorageTypes": len(storageTypes)})
+	return storageTypes, nil
+}
+
+// getDiskPrice returns the pay-as-you-go price per GB per month for the given disk category. The Pricing
+// API quotes the total cost of a diskPriceQuoteSizeGB disk, so it is normalised down to a per-GB rate here
+func (a *AlibabaInfoer) getDiskPrice(region, category string) (float64, error) {
+	response := &bssopenapi.GetPayAsYouGoPriceResponse{}
+
+	getPayAsYouGoPrice, err := a.client.ProcessCommonRequest(a.getDiskPayAsYouGoPriceRequest(region, category, diskPriceQuoteSizeGB))
+	if err != nil {
+		return 0, err
+	}
+
+	if err := json.Unmarshal(getPayAsYouGoPrice.BaseResponse.GetHttpContentBytes(), response); err != nil {
+		return 0, err
+	}
+
+	if !response.Success {
+		return 0, emperror.With(errors.New("failed to get disk price"), response.Code)
+	}
+
+	for _, moduleDetail := range response.Data.ModuleDetails.ModuleDetail {
+		return pricePerGB(moduleDetail.OriginalCost, diskPriceQuoteSizeGB), nil
+	}
+
+	return 0, errors.New("no price returned for disk category")
+}
+
+// pricePerGB normalises the total quoted price of a quoteSizeGB disk down to a per-GB rate
+func pricePerGB(quotedPrice float64, quoteSizeGB int) float64 {
+	if quoteSizeGB <= 0 {
+		return 0
+	}
+	return quotedPrice / float64(quoteSizeGB)
+}
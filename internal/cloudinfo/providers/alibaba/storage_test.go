@@ -0,0 +1,37 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import "testing"
+
+func TestPricePerGB(t *testing.T) {
+	tests := map[string]struct {
+		quotedPrice float64
+		quoteSizeGB int
+		want        float64
+	}{
+		"normal quote":     {quotedPrice: 40, quoteSizeGB: 20, want: 2},
+		"fractional quote": {quotedPrice: 15, quoteSizeGB: 20, want: 0.75},
+		"zero quote size":  {quotedPrice: 40, quoteSizeGB: 0, want: 0},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := pricePerGB(test.quotedPrice, test.quoteSizeGB); got != test.want {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
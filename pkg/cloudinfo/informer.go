@@ -0,0 +1,254 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// threadSafeStore is the default Store implementation, safe for concurrent use by the informer's resync
+// goroutine and any reader goroutine calling Get/List
+type threadSafeStore struct {
+	mu    sync.RWMutex
+	items map[string]interface{}
+}
+
+// NewStore returns an empty, concurrency-safe Store
+func NewStore() Store {
+	return &threadSafeStore{items: make(map[string]interface{})}
+}
+
+func (s *threadSafeStore) Add(key string, obj interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[key] = obj
+}
+
+func (s *threadSafeStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, key)
+}
+
+func (s *threadSafeStore) Get(key string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	obj, ok := s.items[key]
+	return obj, ok
+}
+
+func (s *threadSafeStore) List() []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]interface{}, 0, len(s.items))
+	for _, obj := range s.items {
+		list = append(list, obj)
+	}
+	return list
+}
+
+// versionHash computes a stable hash over the JSON encoding of obj, used to detect whether a resource
+// changed between two List calls without requiring providers to expose their own resource versions
+func versionHash(obj interface{}) (string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// resourceIDFromKey recovers the id segment of a ResourceKey, ie. everything after "provider/region/"
+func resourceIDFromKey(key string) string {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[2]
+}
+
+// PriceExtractor pulls a comparable price out of a tracked object, returning ok=false if obj does not
+// carry one. It is supplied by the caller rather than hardcoded here since pkg/cloudinfo has no
+// provider-specific price types to type-switch on
+type PriceExtractor func(obj interface{}) (price float64, ok bool)
+
+// sharedIndexInformer is the default SharedIndexInformer implementation. It resyncs a single
+// (provider, region, resource kind) tuple by calling ListWatcher.List on a timer and diffing the result
+// against its Store using ResourceKey identity and a version hash of the marshalled object; SDKs that
+// cannot push deltas natively only need to implement List for this to work
+type sharedIndexInformer struct {
+	provider       string
+	region         string
+	kind           ResourceKind
+	lw             ListWatcher
+	resyncPeriod   time.Duration
+	store          Store
+	priceExtractor PriceExtractor
+	priceEvents    chan<- PriceEvent
+
+	mu       sync.Mutex
+	handlers []ResourceEventHandler
+	versions map[string]string
+	synced   bool
+}
+
+// NewSharedIndexInformer creates a SharedIndexInformer for the given (provider, region, kind) tuple.
+// priceExtractor and priceEvents may both be nil if the resource kind tracked is not price data; when set,
+// a PriceEvent is offered to priceEvents for every update where the extracted price actually changed, and
+// dropped if priceEvents is full at that moment
+func NewSharedIndexInformer(provider, region string, kind ResourceKind, lw ListWatcher, resyncPeriod time.Duration, priceExtractor PriceExtractor, priceEvents chan<- PriceEvent) SharedIndexInformer {
+	return &sharedIndexInformer{
+		provider:       provider,
+		region:         region,
+		kind:           kind,
+		lw:             lw,
+		resyncPeriod:   resyncPeriod,
+		store:          NewStore(),
+		priceExtractor: priceExtractor,
+		priceEvents:    priceEvents,
+		versions:       make(map[string]string),
+	}
+}
+
+func (i *sharedIndexInformer) AddEventHandler(handler ResourceEventHandler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = append(i.handlers, handler)
+}
+
+func (i *sharedIndexInformer) HasSynced() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.synced
+}
+
+// Run performs an immediate resync and then one resync per resyncPeriod until ctx is cancelled
+func (i *sharedIndexInformer) Run(ctx context.Context) {
+	i.resync()
+
+	ticker := time.NewTicker(i.resyncPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			i.resync()
+		}
+	}
+}
+
+// resync lists the current state, diffs it against the store by key and version hash, fires the
+// corresponding Add/Update/Delete events, and updates the store to match
+func (i *sharedIndexInformer) resync() {
+	current, err := i.lw.List(i.kind, i.region)
+	if err != nil {
+		return
+	}
+
+	i.mu.Lock()
+	handlers := append([]ResourceEventHandler(nil), i.handlers...)
+	i.mu.Unlock()
+
+	seen := make(map[string]struct{}, len(current))
+
+	for key, obj := range current {
+		seen[key] = struct{}{}
+
+		hash, err := versionHash(obj)
+		if err != nil {
+			continue
+		}
+
+		oldHash, existed := i.versions[key]
+		if !existed {
+			i.store.Add(key, obj)
+			i.versions[key] = hash
+			for _, h := range handlers {
+				h.OnAdd(key, obj)
+			}
+			continue
+		}
+
+		if oldHash == hash {
+			continue
+		}
+
+		oldObj, _ := i.store.Get(key)
+		i.store.Add(key, obj)
+		i.versions[key] = hash
+		for _, h := range handlers {
+			h.OnUpdate(key, oldObj, obj)
+		}
+		i.emitPriceEvent(key, oldObj, obj)
+	}
+
+	for key := range i.versions {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		oldObj, _ := i.store.Get(key)
+		i.store.Delete(key)
+		delete(i.versions, key)
+		for _, h := range handlers {
+			h.OnDelete(key, oldObj)
+		}
+	}
+
+	i.mu.Lock()
+	i.synced = true
+	i.mu.Unlock()
+}
+
+// emitPriceEvent sends a PriceEvent for key if a priceExtractor/priceEvents channel is configured and the
+// extracted price actually changed between oldObj and newObj. The send does not block: if priceEvents is
+// full, the event is dropped
+func (i *sharedIndexInformer) emitPriceEvent(key string, oldObj, newObj interface{}) {
+	if i.priceExtractor == nil || i.priceEvents == nil {
+		return
+	}
+
+	oldPrice, ok := i.priceExtractor(oldObj)
+	if !ok {
+		return
+	}
+	newPrice, ok := i.priceExtractor(newObj)
+	if !ok {
+		return
+	}
+	if oldPrice == newPrice {
+		return
+	}
+
+	select {
+	case i.priceEvents <- PriceEvent{
+		Provider:     i.provider,
+		Region:       i.region,
+		InstanceType: resourceIDFromKey(key),
+		OldPrice:     oldPrice,
+		NewPrice:     newPrice,
+	}:
+	default:
+	}
+}
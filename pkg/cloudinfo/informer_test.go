@@ -0,0 +1,148 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"testing"
+)
+
+// fakeListWatcher returns whatever List was last set to, letting tests drive the informer through a
+// sequence of resyncs
+type fakeListWatcher struct {
+	list map[string]interface{}
+}
+
+func (f *fakeListWatcher) List(kind ResourceKind, region string) (map[string]interface{}, error) {
+	return f.list, nil
+}
+
+// recordingHandler captures every event it receives, in order, for assertions
+type recordingHandler struct {
+	events []string
+}
+
+func (r *recordingHandler) OnAdd(key string, obj interface{}) {
+	r.events = append(r.events, "add:"+key)
+}
+
+func (r *recordingHandler) OnUpdate(key string, oldObj, newObj interface{}) {
+	r.events = append(r.events, "update:"+key)
+}
+
+func (r *recordingHandler) OnDelete(key string, obj interface{}) {
+	r.events = append(r.events, "delete:"+key)
+}
+
+func TestSharedIndexInformerDiffsConsecutiveLists(t *testing.T) {
+	lw := &fakeListWatcher{list: map[string]interface{}{
+		"aws/eu-central-1/m5.large":  map[string]float64{"price": 0.1},
+		"aws/eu-central-1/m5.xlarge": map[string]float64{"price": 0.2},
+	}}
+
+	informer := NewSharedIndexInformer("aws", "eu-central-1", ResourceKindPrice, lw, 0, nil, nil).(*sharedIndexInformer)
+	handler := &recordingHandler{}
+	informer.AddEventHandler(handler)
+
+	informer.resync()
+	if !informer.HasSynced() {
+		t.Fatal("expected informer to be synced after the first resync")
+	}
+	if got, want := len(handler.events), 2; got != want {
+		t.Fatalf("expected %d add events, got %d: %v", want, got, handler.events)
+	}
+
+	// unchanged list: a second resync must not produce any events
+	informer.resync()
+	if got, want := len(handler.events), 2; got != want {
+		t.Fatalf("expected no new events for an unchanged list, got %d: %v", got, handler.events)
+	}
+
+	// change m5.large's price and drop m5.xlarge
+	lw.list = map[string]interface{}{
+		"aws/eu-central-1/m5.large": map[string]float64{"price": 0.15},
+	}
+	informer.resync()
+
+	if got, want := handler.events[2], "update:aws/eu-central-1/m5.large"; got != want {
+		t.Errorf("expected event %q, got %q", want, got)
+	}
+	if got, want := handler.events[3], "delete:aws/eu-central-1/m5.xlarge"; got != want {
+		t.Errorf("expected event %q, got %q", want, got)
+	}
+
+	if _, ok := informer.store.Get("aws/eu-central-1/m5.xlarge"); ok {
+		t.Error("expected the deleted key to be removed from the store")
+	}
+	if obj, ok := informer.store.Get("aws/eu-central-1/m5.large"); !ok || obj.(map[string]float64)["price"] != 0.15 {
+		t.Errorf("expected the store to hold the updated object, got %v", obj)
+	}
+}
+
+func TestSharedIndexInformerEmitsPriceEvents(t *testing.T) {
+	lw := &fakeListWatcher{list: map[string]interface{}{
+		"aws/eu-central-1/m5.large": 0.1,
+	}}
+
+	events := make(chan PriceEvent, 1)
+	extractor := func(obj interface{}) (float64, bool) {
+		price, ok := obj.(float64)
+		return price, ok
+	}
+
+	informer := NewSharedIndexInformer("aws", "eu-central-1", ResourceKindPrice, lw, 0, extractor, events).(*sharedIndexInformer)
+	informer.resync()
+
+	select {
+	case <-events:
+		t.Fatal("did not expect a price event from the initial sync")
+	default:
+	}
+
+	lw.list = map[string]interface{}{
+		"aws/eu-central-1/m5.large": 0.2,
+	}
+	informer.resync()
+
+	select {
+	case event := <-events:
+		if event.InstanceType != "m5.large" || event.OldPrice != 0.1 || event.NewPrice != 0.2 {
+			t.Errorf("unexpected price event: %+v", event)
+		}
+	default:
+		t.Fatal("expected a price event after the price changed")
+	}
+}
+
+func TestThreadSafeStore(t *testing.T) {
+	store := NewStore()
+
+	if _, ok := store.Get("missing"); ok {
+		t.Error("expected Get on an empty store to report not found")
+	}
+
+	store.Add("a", 1)
+	store.Add("b", 2)
+	if got, want := len(store.List()), 2; got != want {
+		t.Errorf("expected %d items, got %d", want, got)
+	}
+
+	store.Delete("a")
+	if _, ok := store.Get("a"); ok {
+		t.Error("expected a deleted key to no longer be present")
+	}
+	if got, want := len(store.List()), 1; got != want {
+		t.Errorf("expected %d items after delete, got %d", want, got)
+	}
+}
@@ -16,6 +16,9 @@ package cloudinfo
 
 import (
 	"context"
+	"math"
+	"sort"
+	"time"
 )
 
 const (
@@ -34,9 +37,20 @@ type CloudInfo interface {
 	// GetProvider retrieves information about the provider
 	GetProvider(provider string) (ProviderDescriber, error)
 
-	// Start starts the product information retrieval in a new goroutine
+	// Start starts the product information retrieval in a new goroutine. Implementations are expected to
+	// run a SharedIndexInformer (see NewSharedIndexInformer) per (provider, region, resource-kind) rather
+	// than a fixed-interval renewal loop, so registered ResourceEventHandlers are notified of Add/Update/
+	// Delete deltas as they are observed, with a shorter resync period for short-lived data (see
+	// HasShortLivedPriceInfo) than for catalog data. The Store/SharedIndexInformer/diffing machinery itself
+	// is implemented in this package; no concrete CloudInfo wiring it up to a provider exists in this module
+	// yet. Alibaba is currently the only provider implementing ListWatcher (List only, diffed by the
+	// informer since its SDK has no native watch); AWS and GCP providers are not part of this module.
 	Start(ctx context.Context)
 
+	// Subscribe registers a handler to receive resource deltas (Add/Update/Delete) observed by the
+	// informer layer for the given resource kind, so consumers can react to changes instead of polling
+	Subscribe(kind ResourceKind, handler ResourceEventHandler)
+
 	// Initialize is called once per product info renewals so it can be used to download a large price descriptor
 	Initialize(provider string) (map[string]map[string]Price, error)
 
@@ -60,6 +74,38 @@ type CloudInfo interface {
 
 	// GetInfoer gets the cloud provider specific Infoer implementation (discriminator for cloud providers)
 	GetInfoer(provider string) (CloudInfoer, error)
+
+	// GetStorageProducts returns the available block storage types for a given provider, service and region,
+	// including per-zone availability and on-demand pricing.
+	// Currently only implemented for Alibaba (ECS disks); AWS EBS and GCP PD support is not yet wired in.
+	GetStorageProducts(provider string, service string, region string) ([]StorageDetails, error)
+
+	// GetProductsFiltered returns the products matching the given set of filters for a provider, service and region,
+	// without requiring the caller to materialise and filter the full catalog.
+	// Currently only implemented for Alibaba, which applies every filter in-memory (DescribeInstanceTypes has
+	// no native filter parameters); an AWS implementation could pass filters straight through to the Pricing
+	// API's get-products call, but that passthrough is not yet wired in.
+	GetProductsFiltered(provider string, service string, region string, filters []PriceFilter) ([]ProductDetails, error)
+
+	// GetReservedPrice returns the reserved-instance / subscription pricing terms available for a given
+	// instance type in a region.
+	// Currently only implemented for Alibaba, derived from GetSubscriptionPrice; AWS (via the Reserved
+	// terms block already returned by get-products) and GCP CUDs are not yet wired in.
+	GetReservedPrice(provider string, region string, instanceType string) ([]ReservedTerm, error)
+
+	// GetNodePoolCapabilities returns what a managed-Kubernetes node pool can be built with for a given
+	// provider, service (eg.: ack, eks, gke, aks, oke) and region.
+	// Currently only implemented for Alibaba ACK, and only InstanceFamilies comes from a live API call
+	// there; see the Alibaba infoer for which fields are fixed defaults. EKS/GKE/AKS/OKE are not
+	// implemented.
+	GetNodePoolCapabilities(provider string, service string, region string) (NodePoolCapabilities, error)
+
+	// GetSpotPriceHistory returns the spot price series observed in each of the given zones for an instance
+	// type, covering the requested time window, so callers can judge price stability rather than just the
+	// latest price.
+	// Currently only implemented for Alibaba; AWS and GCP both expose an equivalent windowed history call,
+	// but those providers are not part of this module yet.
+	GetSpotPriceHistory(provider string, region string, instanceType string, zones []string, window time.Duration) (map[string]SpotPriceSeries, error)
 }
 
 // AttrValue represents an attribute value
@@ -84,6 +130,64 @@ var (
 	NtwExtra = "extra"
 )
 
+const (
+	// TermLength1yr is a one year reservation term
+	TermLength1yr = "1yr"
+	// TermLength3yr is a three year reservation term
+	TermLength3yr = "3yr"
+
+	// PaymentAllUpfront pays the full commitment up front
+	PaymentAllUpfront = "AllUpfront"
+	// PaymentPartialUpfront pays part of the commitment up front, the rest hourly
+	PaymentPartialUpfront = "PartialUpfront"
+	// PaymentNoUpfront pays the commitment hourly over the term, with no upfront fee
+	PaymentNoUpfront = "NoUpfront"
+
+	// OfferingStandard is a standard reserved instance, with no exchange flexibility
+	OfferingStandard = "standard"
+	// OfferingConvertible is a convertible reserved instance that can be exchanged for another configuration
+	OfferingConvertible = "convertible"
+)
+
+// ReservedTerm describes a single reserved-instance or subscription pricing term for an instance type
+type ReservedTerm struct {
+	// TermLength is the length of the commitment, eg.: TermLength1yr, TermLength3yr
+	TermLength string `json:"termLength"`
+
+	// PaymentOption is the upfront payment arrangement, eg.: PaymentAllUpfront, PaymentNoUpfront
+	PaymentOption string `json:"paymentOption"`
+
+	// OfferingClass is OfferingStandard or OfferingConvertible
+	OfferingClass string `json:"offeringClass"`
+
+	// UpfrontFee is the one-time fee paid at the start of the term, zero for PaymentNoUpfront
+	UpfrontFee float64 `json:"upfrontFee"`
+
+	// HourlyRate is the recurring hourly rate charged over the term, zero for PaymentAllUpfront
+	HourlyRate float64 `json:"hourlyRate"`
+
+	// EffectiveHourly is the UpfrontFee amortised over the term plus HourlyRate, ie. the comparable
+	// hourly cost of this term against on-demand pricing
+	EffectiveHourly float64 `json:"effectiveHourly"`
+}
+
+// newReservedTerm creates a new ReservedTerm, computing EffectiveHourly by amortising UpfrontFee over termHours
+func newReservedTerm(termLength, paymentOption, offeringClass string, upfrontFee, hourlyRate float64, termHours float64) *ReservedTerm {
+	effectiveHourly := hourlyRate
+	if termHours > 0 {
+		effectiveHourly += upfrontFee / termHours
+	}
+
+	return &ReservedTerm{
+		TermLength:      termLength,
+		PaymentOption:   paymentOption,
+		OfferingClass:   offeringClass,
+		UpfrontFee:      upfrontFee,
+		HourlyRate:      hourlyRate,
+		EffectiveHourly: effectiveHourly,
+	}
+}
+
 // NetworkPerfMapper operations related  to mapping between virtual machines to network performance categories
 type NetworkPerfMapper interface {
 	// MapNetworkPerf gets the network performance category for the given
@@ -130,6 +234,275 @@ func newProductDetails(vm VmInfo) *ProductDetails {
 	return &pd
 }
 
+// StorageType is the provider specific block-storage category identifier (eg.: cloud_essd, gp3, pd-ssd)
+type StorageType string
+
+// StorageInfo describes a block-storage type that can be attached to a virtual machine
+type StorageInfo struct {
+	// Type names the storage category as reported by the provider
+	Type StorageType `json:"type"`
+
+	// MinSizeGB is the smallest disk size that can be provisioned for this storage type
+	MinSizeGB int `json:"minSizeGB"`
+
+	// MaxSizeGB is the largest disk size that can be provisioned for this storage type
+	MaxSizeGB int `json:"maxSizeGB"`
+
+	// IopsClass describes the provisioned IOPS performance tier, if the provider exposes one
+	IopsClass string `json:"iopsClass,omitempty"`
+
+	// ThroughputClass describes the provisioned throughput performance tier, if the provider exposes one
+	ThroughputClass string `json:"throughputClass,omitempty"`
+
+	// Zones lists the availability zones the storage type can be provisioned in
+	Zones []string `json:"zones"`
+
+	// PricePerGB is the on-demand price per GB per month, in the provider's billing currency
+	PricePerGB float64 `json:"pricePerGB"`
+}
+
+// StorageDetails extended view of the block-storage type details
+type StorageDetails struct {
+	// Embedded struct!
+	StorageInfo
+}
+
+// StorageDetailSource storage details related set of operations
+type StorageDetailSource interface {
+	// GetStorageDetails gathers the storage details information known by telescope
+	GetStorageDetails(provider string, region string) ([]StorageDetails, error)
+}
+
+// newStorageDetails creates a new StorageDetails struct and returns a pointer to it
+func newStorageDetails(si StorageInfo) *StorageDetails {
+	sd := StorageDetails{}
+	sd.StorageInfo = si
+	return &sd
+}
+
+const (
+	// FilterTermMatch requires the field to equal the value
+	FilterTermMatch = "TERM_MATCH"
+
+	// FilterAnyOf requires the field to equal one of the comma-separated values
+	FilterAnyOf = "ANY_OF"
+
+	// FilterNoneOf requires the field to equal none of the comma-separated values
+	FilterNoneOf = "NONE_OF"
+
+	// FilterContains requires the field to contain the value as a substring
+	FilterContains = "CONTAINS"
+
+	// FilterEquals is an alias of FilterTermMatch kept for readability at call sites
+	FilterEquals = "EQUALS"
+)
+
+// PriceFilter narrows down a pricing query to products matching Field against Value, according to Type.
+// It is modelled on the AWS Pricing API's get-products filter list so that it can be passed through
+// to providers whose SDK can express the same semantics natively (eg.: capacitystatus, tenancy, operatingSystem)
+type PriceFilter struct {
+	// Field is the attribute name to filter on (eg.: "operatingSystem", "tenancy", "instanceType")
+	Field string `json:"field"`
+
+	// Type selects the comparison semantics; one of FilterTermMatch, FilterAnyOf, FilterNoneOf, FilterContains, FilterEquals
+	Type string `json:"type"`
+
+	// Value is the value (or comma-separated values for FilterAnyOf/FilterNoneOf) to compare Field against
+	Value string `json:"value"`
+}
+
+// ResourceKind identifies what category of resource a Store/SharedIndexInformer tracks
+type ResourceKind string
+
+const (
+	// ResourceKindVM tracks virtual machine catalog entries
+	ResourceKindVM ResourceKind = "vm"
+	// ResourceKindPrice tracks on-demand/spot prices
+	ResourceKindPrice ResourceKind = "price"
+)
+
+// ResourceKey builds the stable resource key an informer uses to identify a resource across consecutive
+// List calls, eg.: "alibaba/eu-central-1/ecs.g6.large"
+func ResourceKey(provider, region, id string) string {
+	return provider + "/" + region + "/" + id
+}
+
+// ResourceEventHandler reacts to the deltas a SharedIndexInformer detects in its Store
+type ResourceEventHandler interface {
+	// OnAdd is called when a resource is observed for the first time
+	OnAdd(key string, obj interface{})
+
+	// OnUpdate is called when a previously observed resource's version hash changes
+	OnUpdate(key string, oldObj, newObj interface{})
+
+	// OnDelete is called when a previously observed resource is no longer present in a List
+	OnDelete(key string, obj interface{})
+}
+
+// Store holds the last known state of a resource kind, keyed by ResourceKey
+type Store interface {
+	// Add inserts or replaces the object for key
+	Add(key string, obj interface{})
+
+	// Delete removes the object for key, if present
+	Delete(key string)
+
+	// Get returns the object for key and whether it was present
+	Get(key string) (interface{}, bool)
+
+	// List returns every object currently held by the store
+	List() []interface{}
+}
+
+// ListWatcher is implemented by provider Infoers for a resource kind they can enumerate. Providers whose
+// SDK has no native watch only need to implement List; the informer synthesises Add/Update/Delete deltas
+// by diffing consecutive List results using ResourceKey and a version hash computed over the marshalled
+// object
+type ListWatcher interface {
+	// List returns the full current state of the resource kind, keyed by ResourceKey
+	List(kind ResourceKind, region string) (map[string]interface{}, error)
+}
+
+// SharedIndexInformer watches a single (provider, region, resource-kind) tuple and fans out the deltas it
+// observes to registered ResourceEventHandlers
+type SharedIndexInformer interface {
+	// AddEventHandler registers a handler to be notified of Add/Update/Delete events
+	AddEventHandler(handler ResourceEventHandler)
+
+	// Run starts the informer's List/resync loop; it blocks until ctx is cancelled
+	Run(ctx context.Context)
+
+	// HasSynced reports whether the informer has completed its initial List
+	HasSynced() bool
+}
+
+// PriceEvent is emitted by the informer layer when a tracked price changes
+type PriceEvent struct {
+	Provider     string  `json:"provider"`
+	Region       string  `json:"region"`
+	InstanceType string  `json:"instanceType"`
+	Zone         string  `json:"zone,omitempty"`
+	OldPrice     float64 `json:"oldPrice"`
+	NewPrice     float64 `json:"newPrice"`
+}
+
+// SpotPricePoint is a single spot price observation for a zone at a point in time
+type SpotPricePoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Zone      string    `json:"zone"`
+	Price     float64   `json:"price"`
+}
+
+// SpotPriceSeries is a time-ordered series of spot price observations, typically for a single zone,
+// with aggregation helpers on top of the raw series
+type SpotPriceSeries []SpotPricePoint
+
+// MeanPrice returns the arithmetic mean of the series, or 0 for an empty series
+func (s SpotPriceSeries) MeanPrice() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, p := range s {
+		sum += p.Price
+	}
+	return sum / float64(len(s))
+}
+
+// P95Price returns the 95th percentile price of the series, or 0 for an empty series
+func (s SpotPriceSeries) P95Price() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	prices := make([]float64, len(s))
+	for i, p := range s {
+		prices[i] = p.Price
+	}
+	sort.Float64s(prices)
+
+	idx := int(math.Ceil(0.95*float64(len(prices)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(prices) {
+		idx = len(prices) - 1
+	}
+	return prices[idx]
+}
+
+// StdDev returns the population standard deviation of the series, or 0 for a series with fewer than 2 points
+func (s SpotPriceSeries) StdDev() float64 {
+	if len(s) < 2 {
+		return 0
+	}
+
+	mean := s.MeanPrice()
+	var sumSquares float64
+	for _, p := range s {
+		d := p.Price - mean
+		sumSquares += d * d
+	}
+	return math.Sqrt(sumSquares / float64(len(s)))
+}
+
+// InterruptionProbability estimates the likelihood of spot interruption as the fraction of points in the
+// series priced at or above onDemandPrice, as a rough proxy for reclaim risk: a spot price rising to meet
+// the on-demand price is how providers signal capacity pressure. Returns 0 for an empty series or a
+// non-positive onDemandPrice
+func (s SpotPriceSeries) InterruptionProbability(onDemandPrice float64) float64 {
+	if len(s) == 0 || onDemandPrice <= 0 {
+		return 0
+	}
+
+	var crossings int
+	for _, p := range s {
+		if p.Price >= onDemandPrice {
+			crossings++
+		}
+	}
+	return float64(crossings) / float64(len(s))
+}
+
+// NodePoolCapabilities describes what a managed-Kubernetes node pool can be built with for a given
+// service (eg.: ack, eks, gke, aks, oke) in a region
+type NodePoolCapabilities struct {
+	// InstanceFamilies lists the instance families that can be used for node pool members
+	InstanceFamilies []string `json:"instanceFamilies"`
+
+	// DisallowedTypes lists instance types that are supported by the provider in general, but not by
+	// this managed-Kubernetes service
+	DisallowedTypes []string `json:"disallowedTypes,omitempty"`
+
+	// MinNodes is the smallest node count a pool can be scaled to
+	MinNodes int `json:"minNodes"`
+
+	// MaxNodes is the largest node count a pool can be scaled to
+	MaxNodes int `json:"maxNodes"`
+
+	// Images lists the supported node OS images
+	Images []string `json:"images"`
+
+	// NetworkPlugins lists the supported CNI/network-plugin choices
+	NetworkPlugins []string `json:"networkPlugins"`
+
+	// Versions lists the supported Kubernetes versions in the region
+	Versions []string `json:"versions"`
+
+	// SupportsSpot signals whether the pool can be backed by spot/preemptible instances
+	SupportsSpot bool `json:"supportsSpot"`
+
+	// SupportsGPU signals whether the pool can be backed by GPU instances
+	SupportsGPU bool `json:"supportsGPU"`
+
+	// SupportsARM signals whether the pool can be backed by ARM instances
+	SupportsARM bool `json:"supportsARM"`
+
+	// SupportsConfidential signals whether the pool can be backed by confidential-VM instances
+	SupportsConfidential bool `json:"supportsConfidential"`
+}
+
 // ServiceDescriber represents a service; eg.: oke, eks
 // Extend this interface with other operations if needed
 type ServiceDescriber interface {
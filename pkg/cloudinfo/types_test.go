@@ -0,0 +1,72 @@
+// Copyright © 2018 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"math"
+	"testing"
+)
+
+func pricesSeries(prices ...float64) SpotPriceSeries {
+	series := make(SpotPriceSeries, len(prices))
+	for i, p := range prices {
+		series[i] = SpotPricePoint{Zone: "zone-a", Price: p}
+	}
+	return series
+}
+
+func TestSpotPriceSeriesMeanPrice(t *testing.T) {
+	if got := pricesSeries().MeanPrice(); got != 0 {
+		t.Errorf("expected 0 for an empty series, got %v", got)
+	}
+
+	series := pricesSeries(1, 2, 3, 4)
+	if got, want := series.MeanPrice(), 2.5; got != want {
+		t.Errorf("expected mean %v, got %v", want, got)
+	}
+}
+
+func TestSpotPriceSeriesP95Price(t *testing.T) {
+	if got := pricesSeries().P95Price(); got != 0 {
+		t.Errorf("expected 0 for an empty series, got %v", got)
+	}
+
+	series := pricesSeries(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	if got, want := series.P95Price(), 10.0; got != want {
+		t.Errorf("expected p95 %v, got %v", want, got)
+	}
+}
+
+func TestSpotPriceSeriesStdDev(t *testing.T) {
+	if got := pricesSeries(1).StdDev(); got != 0 {
+		t.Errorf("expected 0 for a single-point series, got %v", got)
+	}
+
+	series := pricesSeries(2, 4, 4, 4, 5, 5, 7, 9)
+	if got, want := series.StdDev(), 2.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected stddev %v, got %v", want, got)
+	}
+}
+
+func TestSpotPriceSeriesInterruptionProbability(t *testing.T) {
+	if got := pricesSeries(1, 2).InterruptionProbability(0); got != 0 {
+		t.Errorf("expected 0 for a non-positive on-demand price, got %v", got)
+	}
+
+	series := pricesSeries(1, 2, 3, 4)
+	if got, want := series.InterruptionProbability(3), 0.5; got != want {
+		t.Errorf("expected interruption probability %v, got %v", want, got)
+	}
+}